@@ -0,0 +1,173 @@
+package minipool
+
+import (
+    "errors"
+    "fmt"
+    "math/big"
+    "strconv"
+    "strings"
+
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool/minipool"
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool/node"
+    cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+    "github.com/rocket-pool/smartnode/shared/utils/eth"
+)
+
+
+// Begin a bond reduction on a minipool, starting the scrub period countdown
+func beginReduceBondMinipool(c *cli.Context) error {
+
+    // Only minipools without an already-pending bond reduction are eligible to begin one
+    p, minipoolStatuses, err := getStakingMinipools(c, func(status *minipool.NodeStatus) bool { return !status.IsBondReductionPending() })
+    if err != nil { return err }
+    defer p.Cleanup()
+
+    minipoolAddress, err := selectMinipool(c, p, minipoolStatuses, "begin a bond reduction on")
+    if err != nil { return err }
+
+    newBondAmount, err := getNewBondAmount(c, p)
+    if err != nil { return err }
+
+    result := minipool.BeginReduceBondAmount(p, minipoolAddress, newBondAmount)
+    if result.Error != "" {
+        return errors.New(result.Error)
+    }
+    fmt.Fprintln(p.Output, fmt.Sprintf(
+        "Bond reduction to %.2f ETH for minipool %s has begun; it can be finalized once the scrub period has elapsed",
+        eth.WeiToEth(newBondAmount), minipoolAddress.Hex()))
+    return nil
+
+}
+
+
+// Vote to cancel a pending bond reduction on a minipool
+func cancelReduceBondMinipool(c *cli.Context) error {
+
+    // Only minipools with a pending bond reduction are eligible to have it cancelled
+    p, minipoolStatuses, err := getStakingMinipools(c, func(status *minipool.NodeStatus) bool { return status.IsBondReductionPending() })
+    if err != nil { return err }
+    defer p.Cleanup()
+
+    minipoolAddress, err := selectMinipool(c, p, minipoolStatuses, "cancel the pending bond reduction on")
+    if err != nil { return err }
+
+    result := minipool.VoteCancelReduction(p, minipoolAddress)
+    if result.Error != "" {
+        return errors.New(result.Error)
+    }
+    fmt.Fprintln(p.Output, fmt.Sprintf("Voted to cancel the bond reduction for minipool %s", minipoolAddress.Hex()))
+    return nil
+
+}
+
+
+// Finalize a bond reduction on a minipool once the scrub period has elapsed
+func reduceBondMinipool(c *cli.Context) error {
+
+    // Only minipools with a pending bond reduction are eligible to finalize one (whether the scrub
+    // period has actually elapsed yet is checked by ReduceBondAmount itself)
+    p, minipoolStatuses, err := getStakingMinipools(c, func(status *minipool.NodeStatus) bool { return status.IsBondReductionPending() })
+    if err != nil { return err }
+    defer p.Cleanup()
+
+    minipoolAddress, err := selectMinipool(c, p, minipoolStatuses, "finalize the bond reduction on")
+    if err != nil { return err }
+
+    result := minipool.ReduceBondAmount(p, minipoolAddress)
+    if result.Error != "" {
+        return errors.New(result.Error)
+    }
+    fmt.Fprintln(p.Output, fmt.Sprintf(
+        "Successfully reduced the bond for minipool %s to %.2f ETH",
+        minipoolAddress.Hex(), eth.WeiToEth(result.NewBondAmount)))
+    return nil
+
+}
+
+
+// Initialise services and query eligible (staking) minipools in batch, additionally filtered by
+// eligible(status), which callers use to gate on bond-reduction-pending state as appropriate to
+// the action being performed
+func getStakingMinipools(c *cli.Context, eligible func(*minipool.NodeStatus) bool) (*services.Provider, []*minipool.NodeStatus, error) {
+
+    p, err := services.NewProvider(c, services.ProviderOpts{
+        AM: true,
+        Client: true,
+        CM: true,
+        NodeContract: true,
+        LoadContracts: []string{"rocketNodeAPI", "rocketDAOProtocolSettingsMinipool", "utilAddressSetStorage"},
+        LoadAbis: []string{"rocketMinipool", "rocketNodeContract"},
+        WaitClientSync: true,
+        WaitRocketStorage: true,
+    })
+    if err != nil { return nil, nil, err }
+
+    nodeAccount, _ := p.AM.GetNodeAccount()
+    minipoolAddresses, err := node.GetMinipoolAddresses(nodeAccount.Address, p.CM)
+    if err != nil {
+        p.Cleanup()
+        return nil, nil, err
+    }
+
+    nodeStatuses, err := minipool.GetNodeStatusBatch(p.CM, p.Client, minipoolAddresses, c.Int("batch-size"), c.Int("batch-concurrency"))
+    if err != nil {
+        p.Cleanup()
+        return nil, nil, err
+    }
+
+    stakingMinipools := []*minipool.NodeStatus{}
+    for _, nodeStatus := range nodeStatuses {
+        if nodeStatus.Status == minipool.STAKING && eligible(nodeStatus) {
+            stakingMinipools = append(stakingMinipools, nodeStatus)
+        }
+    }
+
+    return p, stakingMinipools, nil
+
+}
+
+
+// Select a minipool to act on, either from the `--minipool` flag or by prompting interactively
+func selectMinipool(c *cli.Context, p *services.Provider, minipoolStatuses []*minipool.NodeStatus, actionDescription string) (*common.Address, error) {
+
+    if len(minipoolStatuses) == 0 {
+        return nil, fmt.Errorf("no staking minipools are available to %s", actionDescription)
+    }
+
+    if requested := c.String("minipool"); requested != "" {
+        for _, minipoolStatus := range minipoolStatuses {
+            if strings.EqualFold(minipoolStatus.Address.Hex(), requested) {
+                return minipoolStatus.Address, nil
+            }
+        }
+        return nil, fmt.Errorf("minipool %s is not eligible to %s", requested, actionDescription)
+    }
+
+    prompt := []string{fmt.Sprintf("Please select a minipool to %s by entering a number:", actionDescription)}
+    options := []string{}
+    for mi, minipoolStatus := range minipoolStatuses {
+        prompt = append(prompt, fmt.Sprintf("%d: %s", mi + 1, minipoolStatus.Address.Hex()))
+        options = append(options, strconv.Itoa(mi + 1))
+    }
+    response := cliutils.Prompt(p.Input, p.Output, strings.Join(prompt, "\n"), fmt.Sprintf("(?i)^(%s)$", strings.Join(options, "|")), "Please enter a minipool number")
+    index, _ := strconv.Atoi(response)
+    return minipoolStatuses[index - 1].Address, nil
+
+}
+
+
+// Get the new bond amount to reduce to, either from the `--bond-amount` flag or by prompting interactively
+func getNewBondAmount(c *cli.Context, p *services.Provider) (*big.Int, error) {
+
+    if amount := c.String("bond-amount"); amount != "" {
+        return eth.EthToWei(amount), nil
+    }
+
+    response := cliutils.Prompt(p.Input, p.Output, "Please enter the new bond amount in ETH (e.g. '8' to reduce a 16 ETH bond to 8 ETH):", "^[0-9]+(\\.[0-9]+)?$", "Please enter a valid ETH amount")
+    return eth.EthToWei(response), nil
+
+}
@@ -0,0 +1,156 @@
+package minipool
+
+import (
+    "github.com/urfave/cli"
+
+    cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+
+// Flags tuning the Multicall3 batching used to query minipool node statuses, shared by every
+// subcommand that calls minipool.GetNodeStatusBatch
+var batchQueryFlags = []cli.Flag{
+    cli.IntFlag{
+        Name:  "batch-size",
+        Usage: "Number of minipools packed into each Multicall3 aggregate call (defaults to 20)",
+    },
+    cli.IntFlag{
+        Name:  "batch-concurrency",
+        Usage: "Number of batches dispatched in parallel (defaults to 4)",
+    },
+}
+
+// Register commands
+func RegisterCommands(app *cli.App, name string, aliases []string) {
+    app.Commands = append(app.Commands, cli.Command{
+        Name:    name,
+        Aliases: aliases,
+        Usage:   "Manage node minipools",
+        Subcommands: []cli.Command{
+
+            {
+                Name:      "withdraw",
+                Aliases:   []string{"w"},
+                Usage:     "Withdraw a node deposit from one or more minipools",
+                UsageText: "rocketpool minipool withdraw [options]",
+                Flags: append([]cli.Flag{
+                    cli.StringSliceFlag{
+                        Name:  "minipool",
+                        Usage: "The address of a minipool to withdraw from (may be specified multiple times)",
+                    },
+                    cli.BoolFlag{
+                        Name:  "all",
+                        Usage: "Withdraw from all withdrawable minipools (excluding initialized)",
+                    },
+                    cli.BoolFlag{
+                        Name:  "yes",
+                        Usage: "Automatically confirm the gas cost preview and withdrawal without prompting",
+                    },
+                    cli.StringFlag{
+                        Name:  "max-fee",
+                        Usage: "The max fee per gas for withdrawal transactions, in gwei (defaults to the network's suggested max fee)",
+                    },
+                    cli.StringFlag{
+                        Name:  "max-priority-fee",
+                        Usage: "The max priority fee per gas for withdrawal transactions, in gwei (defaults to the network's suggested priority fee)",
+                    },
+                    cli.StringFlag{
+                        Name:  "output",
+                        Usage: "Output format, either empty for human-readable text or 'json'",
+                    },
+                }, batchQueryFlags...),
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil {
+                        return err
+                    }
+
+                    // Run
+                    return withdrawMinipool(c)
+
+                },
+            },
+
+            {
+                Name:      "begin-reduce-bond",
+                Usage:     "Begin a bond reduction on a minipool, starting the scrub period countdown",
+                UsageText: "rocketpool minipool begin-reduce-bond [options]",
+                Flags: append([]cli.Flag{
+                    cli.StringFlag{
+                        Name:  "minipool",
+                        Usage: "The address of the minipool to reduce the bond on",
+                    },
+                    cli.StringFlag{
+                        Name:  "bond-amount",
+                        Usage: "The new bond amount to reduce to, in ETH (e.g. '8')",
+                    },
+                }, batchQueryFlags...),
+                Action: func(c *cli.Context) error {
+
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil {
+                        return err
+                    }
+                    return beginReduceBondMinipool(c)
+
+                },
+            },
+
+            {
+                Name:      "reduce-bond",
+                Usage:     "Finalize a minipool's bond reduction once its scrub period has elapsed",
+                UsageText: "rocketpool minipool reduce-bond [options]",
+                Flags: append([]cli.Flag{
+                    cli.StringFlag{
+                        Name:  "minipool",
+                        Usage: "The address of the minipool to finalize the bond reduction on",
+                    },
+                }, batchQueryFlags...),
+                Action: func(c *cli.Context) error {
+
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil {
+                        return err
+                    }
+                    return reduceBondMinipool(c)
+
+                },
+            },
+
+            {
+                Name:      "history",
+                Aliases:   []string{"h"},
+                Usage:     "Show the node's persisted minipool withdrawal and bond reduction event history",
+                UsageText: "rocketpool minipool history",
+                Action: func(c *cli.Context) error {
+
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil {
+                        return err
+                    }
+                    return minipoolHistory(c)
+
+                },
+            },
+
+            {
+                Name:      "cancel-reduce-bond",
+                Usage:     "Vote to cancel a minipool's pending bond reduction",
+                UsageText: "rocketpool minipool cancel-reduce-bond [options]",
+                Flags: append([]cli.Flag{
+                    cli.StringFlag{
+                        Name:  "minipool",
+                        Usage: "The address of the minipool to cancel the bond reduction on",
+                    },
+                }, batchQueryFlags...),
+                Action: func(c *cli.Context) error {
+
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil {
+                        return err
+                    }
+                    return cancelReduceBondMinipool(c)
+
+                },
+            },
+
+        },
+    })
+}
@@ -0,0 +1,57 @@
+package minipool
+
+import (
+    "fmt"
+
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool/minipool"
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool/node"
+)
+
+
+// Scan the chain for minipool events and display the node's persisted event history
+func minipoolHistory(c *cli.Context) error {
+
+    // Initialise services
+    p, err := services.NewProvider(c, services.ProviderOpts{
+        AM: true,
+        Client: true,
+        CM: true,
+        NodeContract: true,
+        LoadContracts: []string{"rocketNodeAPI", "utilAddressSetStorage"},
+        LoadAbis: []string{"rocketMinipool", "rocketMinipoolDelegateNode", "rocketNodeContract"},
+        WaitClientSync: true,
+        WaitRocketStorage: true,
+    })
+    if err != nil { return err }
+    defer p.Cleanup()
+
+    // Get minipool addresses
+    nodeAccount, _ := p.AM.GetNodeAccount()
+    minipoolAddresses, err := node.GetMinipoolAddresses(nodeAccount.Address, p.CM)
+    if err != nil {
+        return err
+    }
+
+    // Open the persisted event store and bring it up to date with the chain
+    store, err := minipool.OpenEventStore(p.DataPath)
+    if err != nil {
+        return err
+    }
+    if err := minipool.ScanEvents(p, store, minipoolAddresses); err != nil {
+        return err
+    }
+
+    // Display events
+    if len(store.Events) == 0 {
+        fmt.Fprintln(p.Output, "No minipool events recorded yet")
+        return nil
+    }
+    for _, event := range store.Events {
+        fmt.Fprintln(p.Output, event.String())
+    }
+    return nil
+
+}
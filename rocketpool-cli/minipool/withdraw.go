@@ -1,9 +1,9 @@
 package minipool
 
 import (
+    "encoding/json"
     "errors"
     "fmt"
-    "math/big"
     "strconv"
     "strings"
 
@@ -18,16 +18,6 @@ import (
 )
 
 
-// RocketMinipool NodeWithdrawal event
-type NodeWithdrawal struct {
-    To common.Address
-    EtherAmount *big.Int
-    RethAmount *big.Int
-    RplAmount *big.Int
-    Created *big.Int
-}
-
-
 // Withdraw node deposit from a minipool
 func withdrawMinipool(c *cli.Context) error {
 
@@ -45,11 +35,17 @@ func withdrawMinipool(c *cli.Context) error {
     if err != nil { return err }
     defer p.Cleanup()
 
+    jsonOutput := strings.ToLower(c.String("output")) == "json"
+    if jsonOutput && !c.Bool("yes") {
+        return errors.New("--output json requires --yes, since there is no terminal to confirm the gas cost preview against")
+    }
+
     // Check withdrawals are allowed
     withdrawalsAllowed := new(bool)
     if err := p.CM.Contracts["rocketNodeSettings"].Call(nil, withdrawalsAllowed, "getWithdrawalAllowed"); err != nil {
         return errors.New("Error checking node withdrawals enabled status: " + err.Error())
     } else if !*withdrawalsAllowed {
+        if jsonOutput { return printWithdrawJson(p, nil, "Node withdrawals are currently disabled in Rocket Pool") }
         fmt.Fprintln(p.Output, "Node withdrawals are currently disabled in Rocket Pool")
         return nil
     }
@@ -60,42 +56,96 @@ func withdrawMinipool(c *cli.Context) error {
     if err != nil {
         return err
     }
-    minipoolCount := len(minipoolAddresses)
-
-    // Get minipool node statuses
-    nodeStatusChannel := make([]chan *minipool.NodeStatus, minipoolCount)
-    nodeStatusErrorChannel := make(chan error)
-    for mi := 0; mi < minipoolCount; mi++ {
-        nodeStatusChannel[mi] = make(chan *minipool.NodeStatus)
-        go (func(mi int) {
-            if nodeStatus, err := minipool.GetNodeStatus(p.CM, minipoolAddresses[mi]); err != nil {
-                nodeStatusErrorChannel <- err
-            } else {
-                nodeStatusChannel[mi] <- nodeStatus
-            }
-        })(mi)
+
+    // Get minipool node statuses in batches, instead of spawning one goroutine (and several sequential
+    // eth_calls) per minipool
+    nodeStatuses, err := minipool.GetNodeStatusBatch(p.CM, p.Client, minipoolAddresses, c.Int("batch-size"), c.Int("batch-concurrency"))
+    if err != nil {
+        return err
     }
 
-    // Receive minipool node statuses & filter withdrawable minipools
+    // Filter withdrawable minipools
     withdrawableMinipools := []*minipool.NodeStatus{}
-    for mi := 0; mi < minipoolCount; mi++ {
-        select {
-            case nodeStatus := <-nodeStatusChannel[mi]:
-                if (nodeStatus.Status == minipool.INITIALIZED || nodeStatus.Status == minipool.WITHDRAWN || nodeStatus.Status == minipool.TIMED_OUT) && nodeStatus.DepositExists {
-                    withdrawableMinipools = append(withdrawableMinipools, nodeStatus)
-                }
-            case err := <-nodeStatusErrorChannel:
-                return err
+    for _, nodeStatus := range nodeStatuses {
+        if (nodeStatus.Status == minipool.INITIALIZED || nodeStatus.Status == minipool.WITHDRAWN || nodeStatus.Status == minipool.TIMED_OUT) && nodeStatus.DepositExists {
+            withdrawableMinipools = append(withdrawableMinipools, nodeStatus)
         }
     }
 
     // Cancel if no minipools are withdrawable
     if len(withdrawableMinipools) == 0 {
+        if jsonOutput { return printWithdrawJson(p, nil, "No minipools are currently available for withdrawal") }
         fmt.Fprintln(p.Output, "No minipools are currently available for withdrawal")
         return nil
     }
 
-    // Prompt for minipools to withdraw
+    // Determine which minipools to withdraw from, either non-interactively from flags or via prompt
+    withdrawMinipoolAddresses, err := getWithdrawMinipoolAddresses(c, p, withdrawableMinipools)
+    if err != nil {
+        return err
+    }
+
+    // Cancel if no minipools to withdraw
+    if len(withdrawMinipoolAddresses) == 0 {
+        if jsonOutput { return printWithdrawJson(p, nil, "No minipools to withdraw") }
+        fmt.Fprintln(p.Output, "No minipools to withdraw")
+        return nil
+    }
+
+    // Show a gas estimate & cost preview across all selected minipools and confirm before sending
+    // any transactions, unless --yes was passed (required above whenever --output json is set)
+    gasOverrides, err := confirmWithdrawGas(c, p, withdrawMinipoolAddresses)
+    if err != nil {
+        return err
+    }
+
+    // Withdraw node deposits
+    results := minipool.WithdrawBatch(p, withdrawMinipoolAddresses, gasOverrides)
+
+    // Report results
+    if jsonOutput {
+        return printWithdrawJson(p, results, "")
+    }
+    return printWithdrawText(p, results)
+
+}
+
+
+// Determine the minipools to withdraw from, either from the `--minipool`/`--all` flags or, if neither
+// is set, by prompting interactively
+func getWithdrawMinipoolAddresses(c *cli.Context, p *services.Provider, withdrawableMinipools []*minipool.NodeStatus) ([]*common.Address, error) {
+
+    // Non-interactive: --all
+    if c.Bool("all") {
+        addresses := []*common.Address{}
+        for _, minipoolStatus := range withdrawableMinipools {
+            if minipoolStatus.Status != minipool.INITIALIZED {
+                addresses = append(addresses, minipoolStatus.Address)
+            }
+        }
+        return addresses, nil
+    }
+
+    // Non-interactive: --minipool (repeatable)
+    if requested := c.StringSlice("minipool"); len(requested) > 0 {
+        addresses := []*common.Address{}
+        for _, requestedAddress := range requested {
+            var matched *minipool.NodeStatus
+            for _, minipoolStatus := range withdrawableMinipools {
+                if strings.EqualFold(minipoolStatus.Address.Hex(), requestedAddress) {
+                    matched = minipoolStatus
+                    break
+                }
+            }
+            if matched == nil {
+                return nil, fmt.Errorf("minipool %s is not currently available for withdrawal", requestedAddress)
+            }
+            addresses = append(addresses, matched.Address)
+        }
+        return addresses, nil
+    }
+
+    // Interactive prompt
     prompt := []string{"Please select a minipool to withdraw from by entering a number, or enter 'A' for all (excluding initialized):"}
     options := []string{}
     for mi, minipoolStatus := range withdrawableMinipools {
@@ -104,66 +154,109 @@ func withdrawMinipool(c *cli.Context) error {
     }
     response := cliutils.Prompt(p.Input, p.Output, strings.Join(prompt, "\n"), fmt.Sprintf("(?i)^(%s|a|all)$", strings.Join(options, "|")), "Please enter a minipool number or 'A' for all (excluding initialized)")
 
-    // Get addresses of minipools to withdraw
-    withdrawMinipoolAddresses := []*common.Address{}
     if strings.ToLower(response[:1]) == "a" {
+        addresses := []*common.Address{}
         for _, minipoolStatus := range withdrawableMinipools {
             if minipoolStatus.Status != minipool.INITIALIZED {
-                withdrawMinipoolAddresses = append(withdrawMinipoolAddresses, minipoolStatus.Address)
+                addresses = append(addresses, minipoolStatus.Address)
             }
         }
-    } else {
-        index, _ := strconv.Atoi(response)
-        withdrawMinipoolAddresses = append(withdrawMinipoolAddresses, withdrawableMinipools[index - 1].Address)
+        return addresses, nil
     }
-    withdrawMinipoolCount := len(withdrawMinipoolAddresses)
+    index, _ := strconv.Atoi(response)
+    return []*common.Address{withdrawableMinipools[index - 1].Address}, nil
 
-    // Cancel if no minipools to withdraw
-    if withdrawMinipoolCount == 0 {
-        fmt.Fprintln(p.Output, "No minipools to withdraw")
-        return nil
+}
+
+
+// Estimate the aggregate gas cost of the selected withdrawals and, unless --yes was passed, show the
+// user a preview and ask them to confirm before any transaction is signed. `--max-fee` and
+// `--max-priority-fee` (in gwei) override the suggested EIP-1559 gas price for the withdrawal transactions.
+func confirmWithdrawGas(c *cli.Context, p *services.Provider, addresses []*common.Address) (*minipool.GasOverrides, error) {
+
+    estimate, err := minipool.EstimateWithdrawBatchGas(p, addresses)
+    if err != nil {
+        return nil, fmt.Errorf("error estimating withdrawal gas cost: %w", err)
     }
 
-    // Withdraw node deposits
-    withdrawErrors := []string{"Error withdrawing deposits from one or more minipools:"}
-    for mi := 0; mi < withdrawMinipoolCount; mi++ {
-        minipoolAddress := withdrawMinipoolAddresses[mi]
-
-        // Create transactor
-        if txor, err := p.AM.GetNodeAccountTransactor(); err != nil {
-           withdrawErrors = append(withdrawErrors, fmt.Sprintf("Error creating transactor for minipool %s: " + err.Error(), minipoolAddress.Hex()))
-        } else {
-
-            // Send withdrawal transaction
-            fmt.Fprintln(p.Output, fmt.Sprintf("Withdrawing deposit from minipool %s...", minipoolAddress.Hex()))
-            if txReceipt, err := eth.ExecuteContractTransaction(p.Client, txor, p.NodeContractAddress, p.CM.Abis["rocketNodeContract"], "withdrawMinipoolDeposit", minipoolAddress); err != nil {
-                withdrawErrors = append(withdrawErrors, fmt.Sprintf("Error withdrawing deposit from minipool %s: " + err.Error(), minipoolAddress.Hex()))
-            } else {
-
-                // Get withdrawal event
-                if nodeWithdrawalEvents, err := eth.GetTransactionEvents(p.Client, txReceipt, minipoolAddress, p.CM.Abis["rocketMinipoolDelegateNode"], "NodeWithdrawal", NodeWithdrawal{}); err != nil {
-                    withdrawErrors = append(withdrawErrors, fmt.Sprintf("Error retrieving node deposit withdrawal event for minipool %s: " + err.Error(), minipoolAddress.Hex()))
-                } else if len(nodeWithdrawalEvents) == 0 {
-                    withdrawErrors = append(withdrawErrors, fmt.Sprintf("Could not retrieve node deposit withdrawal event for minipool %s", minipoolAddress.Hex()))
-                } else {
-                    nodeWithdrawalEvent := (nodeWithdrawalEvents[0]).(*NodeWithdrawal)
-
-                    // Log
-                    fmt.Fprintln(p.Output, fmt.Sprintf(
-                        "Successfully withdrew deposit of %.2f ETH, %.2f rETH and %.2f RPL from minipool %s",
-                        eth.WeiToEth(nodeWithdrawalEvent.EtherAmount),
-                        eth.WeiToEth(nodeWithdrawalEvent.RethAmount),
-                        eth.WeiToEth(nodeWithdrawalEvent.RplAmount),
-                        minipoolAddress.Hex()))
+    gasOverrides := &minipool.GasOverrides{MaxFeePerGas: estimate.MaxFeePerGas, MaxPriorityFeePerGas: estimate.MaxPriorityFeePerGas}
+    if maxFee := c.String("max-fee"); maxFee != "" {
+        wei, err := eth.GweiToWei(maxFee)
+        if err != nil {
+            return nil, fmt.Errorf("invalid --max-fee: %w", err)
+        }
+        gasOverrides.MaxFeePerGas = wei
+    }
+    if maxPriorityFee := c.String("max-priority-fee"); maxPriorityFee != "" {
+        wei, err := eth.GweiToWei(maxPriorityFee)
+        if err != nil {
+            return nil, fmt.Errorf("invalid --max-priority-fee: %w", err)
+        }
+        gasOverrides.MaxPriorityFeePerGas = wei
+    }
 
-                }
-            }
+    // --yes is required above whenever --output json is set, so reaching the prompt below always
+    // means we have an interactive terminal to prompt against
+    if c.Bool("yes") {
+        return gasOverrides, nil
+    }
+
+    fmt.Fprintln(p.Output, fmt.Sprintf(
+        "Withdrawing from %d minipool(s) will use an estimated %d gas for a total cost of %.6f ETH.",
+        len(addresses), estimate.GasLimit, eth.WeiToEth(estimate.TotalCost)))
+
+    response := cliutils.Prompt(p.Input, p.Output, "Do you want to continue? [y/n]", "(?i)^(y|yes|n|no)$", "Please answer 'y' or 'n'")
+    if strings.ToLower(response[:1]) != "y" {
+        return nil, errors.New("Withdrawal cancelled")
+    }
+
+    return gasOverrides, nil
+
+}
+
+
+// Print withdrawal results as human-readable text
+func printWithdrawText(p *services.Provider, results []*minipool.WithdrawResult) error {
+
+    withdrawErrors := []string{"Error withdrawing deposits from one or more minipools:"}
+    for _, result := range results {
+        if result.Error != "" {
+            withdrawErrors = append(withdrawErrors, result.Error)
+            continue
         }
+        fmt.Fprintln(p.Output, fmt.Sprintf(
+            "Successfully withdrew deposit of %.2f ETH, %.2f rETH and %.2f RPL from minipool %s",
+            eth.WeiToEth(result.EtherAmount),
+            eth.WeiToEth(result.RethAmount),
+            eth.WeiToEth(result.RplAmount),
+            result.Address.Hex()))
     }
 
-    // Return
     if len(withdrawErrors) > 1 { return errors.New(strings.Join(withdrawErrors, "\n")) }
     return nil
 
 }
 
+
+// Response shape for `--output json`, matching the structure printed by `api.PrintResponse`
+type withdrawMinipoolsResponse struct {
+    Message  string                     `json:"message,omitempty"`
+    Minipools []*minipool.WithdrawResult `json:"minipools,omitempty"`
+}
+
+// Print withdrawal results as JSON. Returns a non-nil error if any result failed, mirroring
+// printWithdrawText, so a script gating on exit code sees failure as failure
+func printWithdrawJson(p *services.Provider, results []*minipool.WithdrawResult, message string) error {
+    encoded, err := json.Marshal(withdrawMinipoolsResponse{Message: message, Minipools: results})
+    if err != nil {
+        return err
+    }
+    fmt.Fprintln(p.Output, string(encoded))
+
+    for _, result := range results {
+        if result.Error != "" {
+            return errors.New("Error withdrawing deposits from one or more minipools")
+        }
+    }
+    return nil
+}
@@ -0,0 +1,59 @@
+package minipool
+
+import (
+    "math/big"
+
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool/minipool"
+)
+
+
+// Response for the `minipool begin-reduce-bond` / `reduce-bond` / `cancel-reduce-bond` api commands
+type BondReductionResponse struct {
+    Minipool *minipool.BondReductionResult `json:"minipool"`
+}
+
+
+// Begin a bond reduction on a minipool
+func beginReduceBondAmount(c *cli.Context, minipoolAddress *common.Address, newBondAmount *big.Int) (*BondReductionResponse, error) {
+    p, err := newBondReductionProvider(c)
+    if err != nil { return nil, err }
+    defer p.Cleanup()
+    return &BondReductionResponse{Minipool: minipool.BeginReduceBondAmount(p, minipoolAddress, newBondAmount)}, nil
+}
+
+
+// Vote to cancel a pending bond reduction on a minipool
+func voteCancelReduction(c *cli.Context, minipoolAddress *common.Address) (*BondReductionResponse, error) {
+    p, err := newBondReductionProvider(c)
+    if err != nil { return nil, err }
+    defer p.Cleanup()
+    return &BondReductionResponse{Minipool: minipool.VoteCancelReduction(p, minipoolAddress)}, nil
+}
+
+
+// Finalize a bond reduction on a minipool
+func reduceBondAmount(c *cli.Context, minipoolAddress *common.Address) (*BondReductionResponse, error) {
+    p, err := newBondReductionProvider(c)
+    if err != nil { return nil, err }
+    defer p.Cleanup()
+    return &BondReductionResponse{Minipool: minipool.ReduceBondAmount(p, minipoolAddress)}, nil
+}
+
+
+// Initialise services for the bond reduction api commands
+func newBondReductionProvider(c *cli.Context) (*services.Provider, error) {
+    return services.NewProvider(c, services.ProviderOpts{
+        AM: true,
+        Client: true,
+        CM: true,
+        NodeContract: true,
+        LoadContracts: []string{"rocketNodeAPI", "rocketDAOProtocolSettingsMinipool", "utilAddressSetStorage"},
+        LoadAbis: []string{"rocketMinipool", "rocketNodeContract"},
+        WaitClientSync: true,
+        WaitRocketStorage: true,
+    })
+}
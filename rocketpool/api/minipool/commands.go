@@ -0,0 +1,123 @@
+package minipool
+
+import (
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/utils/api"
+    cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+    "github.com/rocket-pool/smartnode/shared/utils/eth"
+)
+
+// Register subcommands
+func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
+    command.Subcommands = append(command.Subcommands, cli.Command{
+        Name:    name,
+        Aliases: aliases,
+        Usage:   "Manage node minipools",
+        Subcommands: []cli.Command{
+
+            {
+                Name:      "withdraw",
+                Aliases:   []string{"w"},
+                Usage:     "Withdraw a node deposit from one or more minipools",
+                UsageText: "rocketpool api minipool withdraw minipool-address...",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgMinCount(c, 1); err != nil {
+                        return err
+                    }
+                    minipoolAddresses := make([]*common.Address, len(c.Args()))
+                    for ai, arg := range c.Args() {
+                        address := common.HexToAddress(arg)
+                        minipoolAddresses[ai] = &address
+                    }
+
+                    // Run
+                    api.PrintResponse(withdrawMinipools(c, minipoolAddresses))
+                    return nil
+
+                },
+            },
+
+            {
+                Name:      "begin-reduce-bond",
+                Usage:     "Begin a bond reduction on a minipool",
+                UsageText: "rocketpool api minipool begin-reduce-bond minipool-address bond-amount-eth",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 2); err != nil {
+                        return err
+                    }
+                    minipoolAddress := common.HexToAddress(c.Args().Get(0))
+                    newBondAmount := eth.EthToWei(c.Args().Get(1))
+
+                    // Run
+                    api.PrintResponse(beginReduceBondAmount(c, &minipoolAddress, newBondAmount))
+                    return nil
+
+                },
+            },
+
+            {
+                Name:      "reduce-bond",
+                Usage:     "Finalize a minipool's bond reduction once its scrub period has elapsed",
+                UsageText: "rocketpool api minipool reduce-bond minipool-address",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 1); err != nil {
+                        return err
+                    }
+                    minipoolAddress := common.HexToAddress(c.Args().Get(0))
+
+                    // Run
+                    api.PrintResponse(reduceBondAmount(c, &minipoolAddress))
+                    return nil
+
+                },
+            },
+
+            {
+                Name:      "history",
+                Aliases:   []string{"h"},
+                Usage:     "Show the node's persisted minipool withdrawal and bond reduction event history",
+                UsageText: "rocketpool api minipool history",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 0); err != nil {
+                        return err
+                    }
+
+                    // Run
+                    api.PrintResponse(getMinipoolHistory(c))
+                    return nil
+
+                },
+            },
+
+            {
+                Name:      "cancel-reduce-bond",
+                Usage:     "Vote to cancel a minipool's pending bond reduction",
+                UsageText: "rocketpool api minipool cancel-reduce-bond minipool-address",
+                Action: func(c *cli.Context) error {
+
+                    // Validate args
+                    if err := cliutils.ValidateArgCount(c, 1); err != nil {
+                        return err
+                    }
+                    minipoolAddress := common.HexToAddress(c.Args().Get(0))
+
+                    // Run
+                    api.PrintResponse(voteCancelReduction(c, &minipoolAddress))
+                    return nil
+
+                },
+            },
+
+        },
+    })
+}
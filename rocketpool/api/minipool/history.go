@@ -0,0 +1,50 @@
+package minipool
+
+import (
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool/minipool"
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool/node"
+)
+
+
+// Response for the `minipool history` api command
+type HistoryResponse struct {
+    Events []*minipool.Event `json:"events"`
+}
+
+
+// Scan the chain for minipool events and return the node's persisted event history
+func getMinipoolHistory(c *cli.Context) (*HistoryResponse, error) {
+
+    p, err := services.NewProvider(c, services.ProviderOpts{
+        AM: true,
+        Client: true,
+        CM: true,
+        NodeContract: true,
+        LoadContracts: []string{"rocketNodeAPI", "utilAddressSetStorage"},
+        LoadAbis: []string{"rocketMinipool", "rocketMinipoolDelegateNode", "rocketNodeContract"},
+        WaitClientSync: true,
+        WaitRocketStorage: true,
+    })
+    if err != nil { return nil, err }
+    defer p.Cleanup()
+
+    nodeAccount, _ := p.AM.GetNodeAccount()
+    minipoolAddresses, err := node.GetMinipoolAddresses(nodeAccount.Address, p.CM)
+    if err != nil {
+        return nil, err
+    }
+
+    store, err := minipool.OpenEventStore(p.DataPath)
+    if err != nil {
+        return nil, err
+    }
+    if err := minipool.ScanEvents(p, store, minipoolAddresses); err != nil {
+        return nil, err
+    }
+
+    return &HistoryResponse{Events: store.Events}, nil
+
+}
@@ -0,0 +1,38 @@
+package minipool
+
+import (
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/urfave/cli"
+
+    "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool/minipool"
+)
+
+
+// Response for the `minipool withdraw` api command
+type WithdrawResponse struct {
+    Minipools []*minipool.WithdrawResult `json:"minipools"`
+}
+
+
+// Withdraw node deposits from the given minipools
+func withdrawMinipools(c *cli.Context, minipoolAddresses []*common.Address) (*WithdrawResponse, error) {
+
+    // Initialise services
+    p, err := services.NewProvider(c, services.ProviderOpts{
+        AM: true,
+        Client: true,
+        CM: true,
+        NodeContract: true,
+        LoadContracts: []string{"rocketNodeAPI", "rocketNodeSettings", "utilAddressSetStorage"},
+        LoadAbis: []string{"rocketMinipool", "rocketMinipoolDelegateNode", "rocketNodeContract"},
+        WaitClientSync: true,
+        WaitRocketStorage: true,
+    })
+    if err != nil { return nil, err }
+    defer p.Cleanup()
+
+    results := minipool.WithdrawBatch(p, minipoolAddresses, nil)
+    return &WithdrawResponse{Minipools: results}, nil
+
+}
@@ -0,0 +1,170 @@
+package minipool
+
+import (
+    "fmt"
+    "math/big"
+    "sync"
+
+    "github.com/ethereum/go-ethereum/common"
+
+    "github.com/rocket-pool/smartnode/shared/services"
+)
+
+
+// Default number of minipools packed into a single Multicall3 aggregate call, used when the caller
+// doesn't supply a batch size (e.g. via the `--batch-size` flag)
+const MinipoolDetailsBatchSize = 20
+
+// Default number of batches dispatched in parallel, used when the caller doesn't supply a
+// concurrency (e.g. via the `--batch-concurrency` flag)
+const MinipoolDetailsBatchConcurrency = 4
+
+
+// Get the node status of a set of minipools in batches, using a Multicall3-style aggregator to pack
+// Status, DepositExists, StatusBlock and Version reads for up to batchSize minipools into a single eth_call.
+// Batches are dispatched across a worker pool of the given size; if the aggregate3 call for a batch fails
+// outright, or if an individual minipool's reads within it come back unresolved, that minipool is retried
+// individually so one bad minipool does not poison the rest of the batch. A batchSize or concurrency of 0
+// falls back to MinipoolDetailsBatchSize / MinipoolDetailsBatchConcurrency.
+func GetNodeStatusBatch(cm *services.ContractManager, client MulticallClient, minipoolAddresses []*common.Address, batchSize int, concurrency int) ([]*NodeStatus, error) {
+
+    if batchSize <= 0 {
+        batchSize = MinipoolDetailsBatchSize
+    }
+    if concurrency <= 0 {
+        concurrency = MinipoolDetailsBatchConcurrency
+    }
+
+    minipoolCount := len(minipoolAddresses)
+    statuses := make([]*NodeStatus, minipoolCount)
+
+    // Split minipool indices into batches
+    batches := [][]int{}
+    for bi := 0; bi < minipoolCount; bi += batchSize {
+        end := bi + batchSize
+        if end > minipoolCount { end = minipoolCount }
+        indices := make([]int, 0, end - bi)
+        for i := bi; i < end; i++ { indices = append(indices, i) }
+        batches = append(batches, indices)
+    }
+
+    // Dispatch batches across a worker pool
+    batchChannel := make(chan []int, len(batches))
+    for _, batch := range batches { batchChannel <- batch }
+    close(batchChannel)
+
+    errorChannel := make(chan error, minipoolCount)
+    var wg sync.WaitGroup
+    workerCount := concurrency
+    if workerCount > len(batches) { workerCount = len(batches) }
+    for wi := 0; wi < workerCount; wi++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            for batch := range batchChannel {
+
+                addresses := make([]*common.Address, len(batch))
+                for bi, mi := range batch { addresses[bi] = minipoolAddresses[mi] }
+
+                batchStatuses, err := getNodeStatusMulticall(cm, client, addresses)
+                if err != nil {
+
+                    // The whole aggregate3 call failed; retry every minipool in the batch individually
+                    // so a single bad minipool doesn't poison the rest of it
+                    for _, mi := range batch {
+                        if nodeStatus, err := GetNodeStatus(cm, minipoolAddresses[mi]); err != nil {
+                            errorChannel <- fmt.Errorf("error getting node status for minipool %s: %w", minipoolAddresses[mi].Hex(), err)
+                        } else {
+                            statuses[mi] = nodeStatus
+                        }
+                    }
+                    continue
+
+                }
+
+                for bi, mi := range batch {
+                    if batchStatuses[bi] != nil {
+                        statuses[mi] = batchStatuses[bi]
+                        continue
+                    }
+                    // This minipool's own reads within the batch didn't resolve; retry it individually
+                    if nodeStatus, err := GetNodeStatus(cm, minipoolAddresses[mi]); err != nil {
+                        errorChannel <- fmt.Errorf("error getting node status for minipool %s: %w", minipoolAddresses[mi].Hex(), err)
+                    } else {
+                        statuses[mi] = nodeStatus
+                    }
+                }
+
+            }
+        }()
+    }
+    wg.Wait()
+    close(errorChannel)
+
+    if err, ok := <-errorChannel; ok {
+        return nil, err
+    }
+
+    return statuses, nil
+
+}
+
+
+// Aggregate Status, DepositExists, StatusBlock and Version reads for a batch of minipools into a
+// single Multicall3 call
+func getNodeStatusMulticall(cm *services.ContractManager, client MulticallClient, minipoolAddresses []*common.Address) ([]*NodeStatus, error) {
+
+    const callsPerMinipool = 5
+    calls := make([]MulticallCall, 0, len(minipoolAddresses) * callsPerMinipool)
+    for _, minipoolAddress := range minipoolAddresses {
+        calls = append(calls,
+            MulticallCall{Target: *minipoolAddress, Abi: cm.Abis["rocketMinipool"], Method: "getStatus"},
+            MulticallCall{Target: *minipoolAddress, Abi: cm.Abis["rocketMinipool"], Method: "getStatusBlock"},
+            MulticallCall{Target: *minipoolAddress, Abi: cm.Abis["rocketMinipool"], Method: "getNodeDepositExists"},
+            MulticallCall{Target: *minipoolAddress, Abi: cm.Abis["rocketMinipoolDelegateNode"], Method: "version"},
+            MulticallCall{Target: *minipoolAddress, Abi: cm.Abis["rocketMinipool"], Method: "getReduceBondTime"},
+        )
+    }
+
+    // Dispatch the aggregated reads as a single eth_call via Multicall3
+    results, err := Multicall(client, calls)
+    if err != nil {
+        return nil, fmt.Errorf("error aggregating minipool status calls: %w", err)
+    }
+
+    statuses := make([]*NodeStatus, len(minipoolAddresses))
+    for mi, minipoolAddress := range minipoolAddresses {
+        ri := mi * callsPerMinipool
+
+        // getStatus, getStatusBlock, getNodeDepositExists and version are expected to exist on every
+        // minipool version; if Multicall3 reports any of them as failed (e.g. a network hiccup on that
+        // one call), leave this entry nil so the caller falls back to querying it individually
+        status, ok := results[ri].(uint8)
+        statusBlock, okBlock := results[ri + 1].(*big.Int)
+        depositExists, okDeposit := results[ri + 2].(bool)
+        version, okVersion := results[ri + 3].(uint8)
+        if !ok || !okBlock || !okDeposit || !okVersion {
+            continue
+        }
+
+        // getReduceBondTime reverts on minipool versions that predate bond reduction; that's expected
+        // and just means no reduction is (or can be) pending, not a reason to fall back
+        reduceBondTime, _ := results[ri + 4].(*big.Int)
+        if reduceBondTime == nil {
+            reduceBondTime = big.NewInt(0)
+        }
+
+        statuses[mi] = &NodeStatus{
+            Address:        minipoolAddress,
+            Status:         int(status),
+            StatusType:     statusTypes[int(status)],
+            StatusBlock:    statusBlock,
+            DepositExists:  depositExists,
+            Version:        version,
+            ReduceBondTime: reduceBondTime,
+        }
+    }
+
+    return statuses, nil
+
+}
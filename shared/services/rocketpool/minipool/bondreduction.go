@@ -0,0 +1,148 @@
+package minipool
+
+import (
+    "fmt"
+    "math/big"
+    "time"
+
+    "github.com/ethereum/go-ethereum/common"
+
+    "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/utils/eth"
+)
+
+
+// RocketMinipool MinipoolBondReduced event
+type MinipoolBondReducedEvent struct {
+    PreviousBondAmount *big.Int
+    NewBondAmount      *big.Int
+}
+
+
+// Result of a bond reduction action on a single minipool
+type BondReductionResult struct {
+    Address        common.Address `json:"address"`
+    TxHash         common.Hash    `json:"txHash,omitempty"`
+    NewBondAmount  *big.Int       `json:"newBondAmount,omitempty"`
+    Error          string         `json:"error,omitempty"`
+}
+
+
+// Begin a bond reduction on a single minipool, starting the scrub period countdown
+func BeginReduceBondAmount(p *services.Provider, minipoolAddress *common.Address, newBondAmount *big.Int) *BondReductionResult {
+
+    result := &BondReductionResult{Address: *minipoolAddress}
+
+    txor, err := p.AM.GetNodeAccountTransactor()
+    if err != nil {
+        result.Error = fmt.Sprintf("error creating transactor for minipool %s: %s", minipoolAddress.Hex(), err.Error())
+        return result
+    }
+
+    txReceipt, err := eth.ExecuteContractTransaction(p.Client, txor, *minipoolAddress, p.CM.Abis["rocketMinipool"], "beginReduceBondAmount", newBondAmount)
+    if err != nil {
+        result.Error = fmt.Sprintf("error beginning bond reduction for minipool %s: %s", minipoolAddress.Hex(), err.Error())
+        return result
+    }
+    result.TxHash = txReceipt.TxHash
+    result.NewBondAmount = newBondAmount
+
+    return result
+
+}
+
+
+// Vote to cancel a pending bond reduction on a single minipool
+func VoteCancelReduction(p *services.Provider, minipoolAddress *common.Address) *BondReductionResult {
+
+    result := &BondReductionResult{Address: *minipoolAddress}
+
+    txor, err := p.AM.GetNodeAccountTransactor()
+    if err != nil {
+        result.Error = fmt.Sprintf("error creating transactor for minipool %s: %s", minipoolAddress.Hex(), err.Error())
+        return result
+    }
+
+    txReceipt, err := eth.ExecuteContractTransaction(p.Client, txor, *minipoolAddress, p.CM.Abis["rocketMinipool"], "voteCancelReduction")
+    if err != nil {
+        result.Error = fmt.Sprintf("error cancelling bond reduction for minipool %s: %s", minipoolAddress.Hex(), err.Error())
+        return result
+    }
+    result.TxHash = txReceipt.TxHash
+
+    return result
+
+}
+
+
+// Finalize a bond reduction on a single minipool once the scrub period has elapsed, and report the
+// resulting MinipoolBondReduced event amount
+func ReduceBondAmount(p *services.Provider, minipoolAddress *common.Address) *BondReductionResult {
+
+    result := &BondReductionResult{Address: *minipoolAddress}
+
+    // Check the scrub period has elapsed
+    if waitRemaining, err := GetBondReductionWaitRemaining(p, minipoolAddress); err != nil {
+        result.Error = fmt.Sprintf("error checking bond reduction wait time for minipool %s: %s", minipoolAddress.Hex(), err.Error())
+        return result
+    } else if waitRemaining > 0 {
+        result.Error = fmt.Sprintf("minipool %s must wait %d more second(s) before its bond reduction can be finalized", minipoolAddress.Hex(), waitRemaining)
+        return result
+    }
+
+    txor, err := p.AM.GetNodeAccountTransactor()
+    if err != nil {
+        result.Error = fmt.Sprintf("error creating transactor for minipool %s: %s", minipoolAddress.Hex(), err.Error())
+        return result
+    }
+
+    txReceipt, err := eth.ExecuteContractTransaction(p.Client, txor, *minipoolAddress, p.CM.Abis["rocketMinipool"], "reduceBondAmount")
+    if err != nil {
+        result.Error = fmt.Sprintf("error finalizing bond reduction for minipool %s: %s", minipoolAddress.Hex(), err.Error())
+        return result
+    }
+    result.TxHash = txReceipt.TxHash
+
+    bondReducedEvents, err := eth.GetTransactionEvents(p.Client, txReceipt, minipoolAddress, p.CM.Abis["rocketMinipool"], "MinipoolBondReduced", MinipoolBondReducedEvent{})
+    if err != nil {
+        result.Error = fmt.Sprintf("error retrieving bond reduced event for minipool %s: %s", minipoolAddress.Hex(), err.Error())
+        return result
+    }
+    if len(bondReducedEvents) == 0 {
+        result.Error = fmt.Sprintf("could not retrieve bond reduced event for minipool %s", minipoolAddress.Hex())
+        return result
+    }
+    result.NewBondAmount = (bondReducedEvents[0]).(*MinipoolBondReducedEvent).NewBondAmount
+
+    return result
+
+}
+
+
+// Get the number of seconds remaining in a minipool's bond reduction scrub period, or 0 if it has
+// already elapsed (or no reduction is pending)
+func GetBondReductionWaitRemaining(p *services.Provider, minipoolAddress *common.Address) (int64, error) {
+
+    minipoolContract, err := p.CM.NewContract(minipoolAddress, "rocketMinipool")
+    if err != nil {
+        return 0, fmt.Errorf("error initialising minipool contract at %s: %w", minipoolAddress.Hex(), err)
+    }
+
+    reduceBondTime := new(*big.Int)
+    if err := minipoolContract.Call(nil, reduceBondTime, "getReduceBondTime"); err != nil {
+        return 0, fmt.Errorf("error getting bond reduction start time: %w", err)
+    }
+
+    scrubPeriod := new(*big.Int)
+    if err := p.CM.Contracts["rocketDAOProtocolSettingsMinipool"].Call(nil, scrubPeriod, "getBondReductionWindowStart"); err != nil {
+        return 0, fmt.Errorf("error getting bond reduction scrub period: %w", err)
+    }
+
+    elapseAt := new(big.Int).Add(*reduceBondTime, *scrubPeriod)
+    remaining := new(big.Int).Sub(elapseAt, big.NewInt(time.Now().Unix()))
+    if remaining.Sign() < 0 {
+        return 0, nil
+    }
+    return remaining.Int64(), nil
+
+}
@@ -0,0 +1,247 @@
+package minipool
+
+import (
+    "context"
+    "encoding/json"
+    "fmt"
+    "io/ioutil"
+    "math/big"
+    "os"
+    "path/filepath"
+
+    ethereum "github.com/ethereum/go-ethereum"
+    "github.com/ethereum/go-ethereum/common"
+
+    "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/utils/eth"
+)
+
+
+// Number of blocks scanned per eth_getLogs call
+const EventScanInterval = 10000
+
+// Block Rocket Pool's contracts were first deployed at; used as a floor for a fresh event store's
+// first scan so it doesn't walk over a thousand empty block windows from genesis
+const MinipoolEventsDeployBlock = 13325304 // Rocket Pool mainnet launch block
+
+// File the persisted minipool event history is stored under, relative to the node data path
+const eventStoreFileName = "minipool-events.json"
+
+
+// A single persisted minipool event
+type Event struct {
+    Type            string         `json:"type"` // NodeWithdrawal, MinipoolBondReduced or StatusUpdated
+    MinipoolAddress common.Address `json:"minipoolAddress"`
+    BlockNumber     uint64         `json:"blockNumber"`
+    TxHash          common.Hash    `json:"txHash"`
+    EtherAmount     *big.Int       `json:"etherAmount,omitempty"`
+    RethAmount      *big.Int       `json:"rethAmount,omitempty"`
+    RplAmount       *big.Int       `json:"rplAmount,omitempty"`
+    NewBondAmount   *big.Int       `json:"newBondAmount,omitempty"`
+    Status          *int           `json:"status,omitempty"`
+}
+
+
+// Persisted, on-disk history of minipool events for a node
+type EventStore struct {
+    path             string
+    Events           []*Event `json:"events"`
+    LastScannedBlock uint64   `json:"lastScannedBlock"`
+}
+
+
+// Open (or initialise) the event store at the given node data path
+func OpenEventStore(dataPath string) (*EventStore, error) {
+
+    store := &EventStore{path: filepath.Join(dataPath, eventStoreFileName)}
+
+    data, err := ioutil.ReadFile(store.path)
+    if os.IsNotExist(err) {
+        return store, nil
+    } else if err != nil {
+        return nil, fmt.Errorf("error reading minipool event store: %w", err)
+    }
+
+    if err := json.Unmarshal(data, store); err != nil {
+        return nil, fmt.Errorf("error decoding minipool event store: %w", err)
+    }
+    return store, nil
+
+}
+
+
+// Append an event to the store and persist it to disk, skipping it if an equivalent event
+// (same type, minipool and transaction) is already recorded
+func (store *EventStore) Append(event *Event) error {
+    if store.has(event) {
+        return nil
+    }
+    store.Events = append(store.Events, event)
+    return store.save()
+}
+
+
+func (store *EventStore) save() error {
+    data, err := json.Marshal(store)
+    if err != nil {
+        return fmt.Errorf("error encoding minipool event store: %w", err)
+    }
+    if err := ioutil.WriteFile(store.path, data, 0644); err != nil {
+        return fmt.Errorf("error writing minipool event store: %w", err)
+    }
+    return nil
+}
+
+
+// Walk the chain in fixed-size block windows from the store's last scanned block to the current
+// head, decoding NodeWithdrawal, MinipoolBondReduced and StatusUpdated events for the given
+// minipools and persisting them to the store
+func ScanEvents(p *services.Provider, store *EventStore, minipoolAddresses []*common.Address) error {
+
+    currentBlock, err := p.Client.BlockNumber(context.Background())
+    if err != nil {
+        return fmt.Errorf("error getting current block number: %w", err)
+    }
+
+    addresses := make([]common.Address, len(minipoolAddresses))
+    for ai, address := range minipoolAddresses { addresses[ai] = *address }
+
+    minipoolAbi := p.CM.Abis["rocketMinipool"]
+    minipoolDelegateAbi := p.CM.Abis["rocketMinipoolDelegateNode"]
+
+    nodeWithdrawalTopic := minipoolDelegateAbi.Events["NodeWithdrawal"].ID
+    bondReducedTopic := minipoolAbi.Events["MinipoolBondReduced"].ID
+    statusUpdatedTopic := minipoolAbi.Events["StatusUpdated"].ID
+
+    fromBlock := store.LastScannedBlock + 1
+    if store.LastScannedBlock == 0 && MinipoolEventsDeployBlock > fromBlock {
+        // Fresh store: skip straight to the deploy block instead of scanning from genesis
+        fromBlock = MinipoolEventsDeployBlock
+    }
+
+    for ; fromBlock <= currentBlock; fromBlock += EventScanInterval {
+
+        toBlock := fromBlock + EventScanInterval - 1
+        if toBlock > currentBlock { toBlock = currentBlock }
+
+        logs, err := p.Client.FilterLogs(context.Background(), ethereum.FilterQuery{
+            FromBlock: new(big.Int).SetUint64(fromBlock),
+            ToBlock:   new(big.Int).SetUint64(toBlock),
+            Addresses: addresses,
+            Topics:    [][]common.Hash{{nodeWithdrawalTopic, bondReducedTopic, statusUpdatedTopic}},
+        })
+        if err != nil {
+            return fmt.Errorf("error scanning minipool events from block %d to %d: %w", fromBlock, toBlock, err)
+        }
+
+        for _, log := range logs {
+
+            event := &Event{MinipoolAddress: log.Address, BlockNumber: log.BlockNumber, TxHash: log.TxHash}
+
+            switch log.Topics[0] {
+
+                case nodeWithdrawalTopic:
+                    decoded := new(NodeWithdrawalEvent)
+                    if err := minipoolDelegateAbi.UnpackIntoInterface(decoded, "NodeWithdrawal", log.Data); err != nil {
+                        return fmt.Errorf("error decoding NodeWithdrawal event: %w", err)
+                    }
+                    event.Type = "NodeWithdrawal"
+                    event.EtherAmount = decoded.EtherAmount
+                    event.RethAmount = decoded.RethAmount
+                    event.RplAmount = decoded.RplAmount
+
+                case bondReducedTopic:
+                    decoded := new(MinipoolBondReducedEvent)
+                    if err := minipoolAbi.UnpackIntoInterface(decoded, "MinipoolBondReduced", log.Data); err != nil {
+                        return fmt.Errorf("error decoding MinipoolBondReduced event: %w", err)
+                    }
+                    event.Type = "MinipoolBondReduced"
+                    event.NewBondAmount = decoded.NewBondAmount
+
+                case statusUpdatedTopic:
+                    decoded := new(struct{ Status uint8 })
+                    if err := minipoolAbi.UnpackIntoInterface(decoded, "StatusUpdated", log.Data); err != nil {
+                        return fmt.Errorf("error decoding StatusUpdated event: %w", err)
+                    }
+                    status := int(decoded.Status)
+                    event.Type = "StatusUpdated"
+                    event.Status = &status
+
+            }
+
+            // Skip events already recorded (e.g. a withdrawal the CLI/api already persisted live via
+            // recordWithdrawalEvent before this range was scanned)
+            if store.has(event) {
+                continue
+            }
+            store.Events = append(store.Events, event)
+
+        }
+
+        // Persist once per block range rather than once per event
+        store.LastScannedBlock = toBlock
+        if err := store.save(); err != nil {
+            return err
+        }
+
+    }
+
+    return nil
+
+}
+
+
+// Whether an equivalent event (same type, minipool and transaction) is already recorded
+func (store *EventStore) has(event *Event) bool {
+    for _, existing := range store.Events {
+        if existing.Type == event.Type && existing.MinipoolAddress == event.MinipoolAddress && existing.TxHash == event.TxHash {
+            return true
+        }
+    }
+    return false
+}
+
+
+// Record a live withdrawal (one the CLI or api just performed) into the event store, so
+// historical and live withdrawals share one view. Best-effort: the withdrawal has already
+// succeeded on-chain by this point, so a local persistence failure here is not reported as a
+// withdrawal failure - the event will simply be picked up later by ScanEvents instead.
+func recordWithdrawalEvent(p *services.Provider, result *WithdrawResult) {
+
+    if result.Error != "" {
+        return
+    }
+
+    store, err := OpenEventStore(p.DataPath)
+    if err != nil {
+        return
+    }
+
+    store.Append(&Event{
+        Type:            "NodeWithdrawal",
+        MinipoolAddress: result.Address,
+        TxHash:          result.TxHash,
+        EtherAmount:     result.EtherAmount,
+        RethAmount:      result.RethAmount,
+        RplAmount:       result.RplAmount,
+    })
+
+}
+
+
+// Convenience helper for formatting a persisted event's ETH-denominated amounts
+func (event *Event) String() string {
+    switch event.Type {
+        case "NodeWithdrawal":
+            return fmt.Sprintf("[block %d] Withdrew %.2f ETH, %.2f rETH and %.2f RPL from minipool %s (tx %s)",
+                event.BlockNumber, eth.WeiToEth(event.EtherAmount), eth.WeiToEth(event.RethAmount), eth.WeiToEth(event.RplAmount), event.MinipoolAddress.Hex(), event.TxHash.Hex())
+        case "MinipoolBondReduced":
+            return fmt.Sprintf("[block %d] Reduced bond on minipool %s to %.2f ETH (tx %s)",
+                event.BlockNumber, event.MinipoolAddress.Hex(), eth.WeiToEth(event.NewBondAmount), event.TxHash.Hex())
+        case "StatusUpdated":
+            return fmt.Sprintf("[block %d] Minipool %s status changed to %s (tx %s)",
+                event.BlockNumber, event.MinipoolAddress.Hex(), statusTypes[*event.Status], event.TxHash.Hex())
+        default:
+            return fmt.Sprintf("[block %d] %s event on minipool %s (tx %s)", event.BlockNumber, event.Type, event.MinipoolAddress.Hex(), event.TxHash.Hex())
+    }
+}
@@ -0,0 +1,110 @@
+package minipool
+
+import (
+    "context"
+    "fmt"
+    "math/big"
+    "strings"
+
+    ethereum "github.com/ethereum/go-ethereum"
+    "github.com/ethereum/go-ethereum/accounts/abi"
+    "github.com/ethereum/go-ethereum/common"
+)
+
+
+// Multicall3 is deployed at the same address on every chain Rocket Pool supports
+var multicall3Address = common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11")
+
+// Only the aggregate3 function is needed here
+const multicall3AbiJson = `[{"inputs":[{"components":[{"internalType":"address","name":"target","type":"address"},{"internalType":"bool","name":"allowFailure","type":"bool"},{"internalType":"bytes","name":"callData","type":"bytes"}],"internalType":"struct IMulticall3.Call3[]","name":"calls","type":"tuple[]"}],"name":"aggregate3","outputs":[{"components":[{"internalType":"bool","name":"success","type":"bool"},{"internalType":"bytes","name":"returnData","type":"bytes"}],"internalType":"struct IMulticall3.Result[]","name":"returnData","type":"tuple[]"}],"stateMutability":"payable","type":"function"}]`
+
+var multicall3Abi abi.ABI
+
+func init() {
+    parsed, err := abi.JSON(strings.NewReader(multicall3AbiJson))
+    if err != nil {
+        panic(fmt.Sprintf("error parsing multicall3 abi: %s", err.Error()))
+    }
+    multicall3Abi = parsed
+}
+
+
+// A single read call to aggregate via Multicall3
+type MulticallCall struct {
+    Target common.Address
+    Abi    abi.ABI
+    Method string
+    Args   []interface{}
+}
+
+type multicall3Call3 struct {
+    Target       common.Address
+    AllowFailure bool
+    CallData     []byte
+}
+
+type multicall3Result struct {
+    Success    bool
+    ReturnData []byte
+}
+
+
+// The subset of ethclient.Client that Multicall needs; narrowed to an interface so callers can
+// substitute a fake in tests without standing up a real or simulated chain
+type MulticallClient interface {
+    CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+}
+
+
+// Aggregate a batch of read calls into a single eth_call via Multicall3's aggregate3, returning the
+// first decoded return value of each call in the same order they were given. Each call is made with
+// allowFailure=true, so one reverting call (e.g. a method that doesn't exist on an older minipool
+// version) doesn't revert the whole aggregate3 batch; its result is simply nil in the returned slice,
+// and callers should fall back to querying that entry individually.
+func Multicall(client MulticallClient, calls []MulticallCall) ([]interface{}, error) {
+
+    call3s := make([]multicall3Call3, len(calls))
+    for ci, call := range calls {
+        callData, err := call.Abi.Pack(call.Method, call.Args...)
+        if err != nil {
+            return nil, fmt.Errorf("error encoding input data for %s: %w", call.Method, err)
+        }
+        call3s[ci] = multicall3Call3{Target: call.Target, AllowFailure: true, CallData: callData}
+    }
+
+    input, err := multicall3Abi.Pack("aggregate3", call3s)
+    if err != nil {
+        return nil, fmt.Errorf("error encoding aggregate3 call: %w", err)
+    }
+
+    output, err := client.CallContract(context.Background(), ethereum.CallMsg{To: &multicall3Address, Data: input}, nil)
+    if err != nil {
+        return nil, fmt.Errorf("error calling multicall3 aggregate3: %w", err)
+    }
+
+    var decoded struct{ ReturnData []multicall3Result }
+    if err := multicall3Abi.UnpackIntoInterface(&decoded, "aggregate3", output); err != nil {
+        return nil, fmt.Errorf("error decoding aggregate3 result: %w", err)
+    }
+    if len(decoded.ReturnData) != len(calls) {
+        return nil, fmt.Errorf("expected %d multicall results, got %d", len(calls), len(decoded.ReturnData))
+    }
+
+    results := make([]interface{}, len(calls))
+    for ci, call := range calls {
+        result := decoded.ReturnData[ci]
+        if !result.Success {
+            continue
+        }
+        outputs, err := call.Abi.Unpack(call.Method, result.ReturnData)
+        if err != nil {
+            return nil, fmt.Errorf("error decoding result of %s on %s: %w", call.Method, call.Target.Hex(), err)
+        }
+        if len(outputs) > 0 {
+            results[ci] = outputs[0]
+        }
+    }
+
+    return results, nil
+
+}
@@ -0,0 +1,129 @@
+package minipool
+
+import (
+    "context"
+    "math/big"
+    "strings"
+    "testing"
+
+    ethereum "github.com/ethereum/go-ethereum"
+    "github.com/ethereum/go-ethereum/accounts/abi"
+    "github.com/ethereum/go-ethereum/common"
+
+    "github.com/rocket-pool/smartnode/shared/services"
+)
+
+
+const testMinipoolAbiJson = `[
+    {"name":"getStatus","type":"function","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint8"}]},
+    {"name":"getStatusBlock","type":"function","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint256"}]},
+    {"name":"getNodeDepositExists","type":"function","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"bool"}]},
+    {"name":"getReduceBondTime","type":"function","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint256"}]}
+]`
+
+const testMinipoolDelegateAbiJson = `[
+    {"name":"version","type":"function","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint8"}]}
+]`
+
+
+// Fake MulticallClient that returns a fixed sequence of aggregate3 results, standing in for a real
+// Multicall3 deployment so the encode/dispatch/decode path can be exercised without a live chain
+type fakeMulticallClient struct {
+    results []multicall3Result
+}
+
+func (f *fakeMulticallClient) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+    return multicall3Abi.Methods["aggregate3"].Outputs.Pack(f.results)
+}
+
+
+// Test that Multicall correctly decodes a mix of successful and failed (allowFailure) calls
+func TestMulticall(t *testing.T) {
+
+    minipoolAbi, err := abi.JSON(strings.NewReader(testMinipoolAbiJson))
+    if err != nil { t.Fatal(err) }
+
+    target := common.HexToAddress("0x1111111111111111111111111111111111111111")
+
+    statusData, err := minipoolAbi.Methods["getStatus"].Outputs.Pack(uint8(2))
+    if err != nil { t.Fatal(err) }
+    blockData, err := minipoolAbi.Methods["getStatusBlock"].Outputs.Pack(big.NewInt(12345))
+    if err != nil { t.Fatal(err) }
+
+    client := &fakeMulticallClient{results: []multicall3Result{
+        {Success: true, ReturnData: statusData},
+        {Success: false, ReturnData: nil}, // simulates a revert on an older minipool version
+        {Success: true, ReturnData: blockData},
+    }}
+
+    results, err := Multicall(client, []MulticallCall{
+        {Target: target, Abi: minipoolAbi, Method: "getStatus"},
+        {Target: target, Abi: minipoolAbi, Method: "getReduceBondTime"},
+        {Target: target, Abi: minipoolAbi, Method: "getStatusBlock"},
+    })
+    if err != nil { t.Fatal(err) }
+
+    if status, ok := results[0].(uint8); !ok || status != 2 {
+        t.Errorf("expected results[0] to decode as uint8(2), got %#v", results[0])
+    }
+    if results[1] != nil {
+        t.Errorf("expected a failed call to decode as nil, got %#v", results[1])
+    }
+    if block, ok := results[2].(*big.Int); !ok || block.Cmp(big.NewInt(12345)) != 0 {
+        t.Errorf("expected results[2] to decode as *big.Int(12345), got %#v", results[2])
+    }
+
+}
+
+
+// Test getNodeStatusMulticall end-to-end: encoding the per-minipool call batch, decoding a mix of
+// successful and failed (e.g. pre-bond-reduction minipool version) results, and falling back to nil
+// only for minipools whose required reads didn't resolve
+func TestGetNodeStatusMulticall(t *testing.T) {
+
+    minipoolAbi, err := abi.JSON(strings.NewReader(testMinipoolAbiJson))
+    if err != nil { t.Fatal(err) }
+    minipoolDelegateAbi, err := abi.JSON(strings.NewReader(testMinipoolDelegateAbiJson))
+    if err != nil { t.Fatal(err) }
+
+    cm := &services.ContractManager{Abis: map[string]abi.ABI{
+        "rocketMinipool":             minipoolAbi,
+        "rocketMinipoolDelegateNode": minipoolDelegateAbi,
+    }}
+
+    addressA := common.HexToAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+    addressB := common.HexToAddress("0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+
+    statusData, _ := minipoolAbi.Methods["getStatus"].Outputs.Pack(uint8(STAKING))
+    blockData, _ := minipoolAbi.Methods["getStatusBlock"].Outputs.Pack(big.NewInt(100))
+    depositData, _ := minipoolAbi.Methods["getNodeDepositExists"].Outputs.Pack(true)
+    versionData, _ := minipoolDelegateAbi.Methods["version"].Outputs.Pack(uint8(3))
+    reduceBondTimeData, _ := minipoolAbi.Methods["getReduceBondTime"].Outputs.Pack(big.NewInt(0))
+
+    // Minipool A: fully resolves, including a pre-bond-reduction version whose getReduceBondTime reverts
+    // Minipool B: fully resolves with a real getReduceBondTime value
+    client := &fakeMulticallClient{results: []multicall3Result{
+        {Success: true, ReturnData: statusData},
+        {Success: true, ReturnData: blockData},
+        {Success: true, ReturnData: depositData},
+        {Success: true, ReturnData: versionData},
+        {Success: false, ReturnData: nil}, // getReduceBondTime reverts for minipool A
+        {Success: true, ReturnData: statusData},
+        {Success: true, ReturnData: blockData},
+        {Success: true, ReturnData: depositData},
+        {Success: true, ReturnData: versionData},
+        {Success: true, ReturnData: reduceBondTimeData},
+    }}
+
+    statuses, err := getNodeStatusMulticall(cm, client, []*common.Address{&addressA, &addressB})
+    if err != nil { t.Fatal(err) }
+
+    if statuses[0] == nil { t.Fatal("expected minipool A to resolve despite its reverting getReduceBondTime call") }
+    if statuses[0].Status != STAKING { t.Errorf("expected minipool A status STAKING, got %d", statuses[0].Status) }
+    if statuses[0].IsBondReductionPending() { t.Error("expected minipool A to have no bond reduction pending") }
+
+    if statuses[1] == nil { t.Fatal("expected minipool B to resolve") }
+    if !statuses[1].DepositExists { t.Error("expected minipool B to have a node deposit") }
+    if statuses[1].Version != 3 { t.Errorf("expected minipool B version 3, got %d", statuses[1].Version) }
+
+}
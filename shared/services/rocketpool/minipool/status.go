@@ -0,0 +1,93 @@
+package minipool
+
+import (
+    "fmt"
+    "math/big"
+
+    "github.com/ethereum/go-ethereum/common"
+
+    "github.com/rocket-pool/smartnode/shared/services"
+)
+
+
+// Minipool statuses
+const (
+    INITIALIZED = iota
+    PRELAUNCH
+    STAKING
+    WITHDRAWN
+    TIMED_OUT
+)
+var statusTypes = map[int]string{
+    INITIALIZED: "Initialized",
+    PRELAUNCH:   "Prelaunch",
+    STAKING:     "Staking",
+    WITHDRAWN:   "Withdrawn",
+    TIMED_OUT:   "Timed out",
+}
+
+
+// Minipool node status
+type NodeStatus struct {
+    Address        *common.Address
+    Status         int
+    StatusType     string
+    StatusBlock    *big.Int
+    DepositExists  bool
+    Version        uint8
+    ReduceBondTime *big.Int // Start time of a pending bond reduction, or 0 if none is pending
+}
+
+// Whether the minipool has a bond reduction currently pending (i.e. past begin-reduce-bond but not
+// yet finalized or cancelled)
+func (status *NodeStatus) IsBondReductionPending() bool {
+    return status.ReduceBondTime != nil && status.ReduceBondTime.Sign() > 0
+}
+
+
+// Get the node status of a minipool
+func GetNodeStatus(cm *services.ContractManager, minipoolAddress *common.Address) (*NodeStatus, error) {
+
+    minipoolContract, err := cm.NewContract(minipoolAddress, "rocketMinipool")
+    if err != nil {
+        return nil, fmt.Errorf("error initialising minipool contract at %s: %w", minipoolAddress.Hex(), err)
+    }
+
+    status := new(uint8)
+    if err := minipoolContract.Call(nil, status, "getStatus"); err != nil {
+        return nil, fmt.Errorf("error getting minipool status: %w", err)
+    }
+
+    statusBlock := new(*big.Int)
+    if err := minipoolContract.Call(nil, statusBlock, "getStatusBlock"); err != nil {
+        return nil, fmt.Errorf("error getting minipool status block: %w", err)
+    }
+
+    depositExists := new(bool)
+    if err := minipoolContract.Call(nil, depositExists, "getNodeDepositExists"); err != nil {
+        return nil, fmt.Errorf("error getting minipool deposit exists status: %w", err)
+    }
+
+    version := new(uint8)
+    if err := minipoolContract.Call(nil, version, "version"); err != nil {
+        return nil, fmt.Errorf("error getting minipool version: %w", err)
+    }
+
+    // getReduceBondTime reverts on minipool versions that predate bond reduction, which just means
+    // no reduction is (or can be) pending
+    reduceBondTime := new(*big.Int)
+    if err := minipoolContract.Call(nil, reduceBondTime, "getReduceBondTime"); err != nil || *reduceBondTime == nil {
+        *reduceBondTime = big.NewInt(0)
+    }
+
+    return &NodeStatus{
+        Address:        minipoolAddress,
+        Status:         int(*status),
+        StatusType:     statusTypes[int(*status)],
+        StatusBlock:    *statusBlock,
+        DepositExists:  *depositExists,
+        Version:        *version,
+        ReduceBondTime: *reduceBondTime,
+    }, nil
+
+}
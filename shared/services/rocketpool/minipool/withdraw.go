@@ -0,0 +1,132 @@
+package minipool
+
+import (
+    "fmt"
+    "math/big"
+
+    "github.com/ethereum/go-ethereum/common"
+
+    "github.com/rocket-pool/smartnode/shared/services"
+    "github.com/rocket-pool/smartnode/shared/utils/eth"
+)
+
+
+// RocketMinipool NodeWithdrawal event
+type NodeWithdrawalEvent struct {
+    To common.Address
+    EtherAmount *big.Int
+    RethAmount *big.Int
+    RplAmount *big.Int
+    Created *big.Int
+}
+
+
+// Result of withdrawing a node deposit from a single minipool
+type WithdrawResult struct {
+    Address     common.Address `json:"address"`
+    TxHash      common.Hash    `json:"txHash,omitempty"`
+    EtherAmount *big.Int       `json:"etherAmount,omitempty"`
+    RethAmount  *big.Int       `json:"rethAmount,omitempty"`
+    RplAmount   *big.Int       `json:"rplAmount,omitempty"`
+    Error       string         `json:"error,omitempty"`
+}
+
+
+// Optional EIP-1559 gas price overrides for a withdrawal transaction
+type GasOverrides struct {
+    MaxFeePerGas         *big.Int
+    MaxPriorityFeePerGas *big.Int
+}
+
+
+// Estimate the gas cost of withdrawing a node deposit from a set of minipools, aggregated into a
+// single total so a user withdrawing from several minipools at once sees one combined estimate
+func EstimateWithdrawBatchGas(p *services.Provider, minipoolAddresses []*common.Address) (*eth.GasEstimate, error) {
+
+    total := &eth.GasEstimate{MaxFeePerGas: big.NewInt(0), MaxPriorityFeePerGas: big.NewInt(0), TotalCost: big.NewInt(0)}
+    for _, minipoolAddress := range minipoolAddresses {
+        estimate, err := EstimateWithdrawGas(p, minipoolAddress)
+        if err != nil {
+            return nil, err
+        }
+        total.GasLimit += estimate.GasLimit
+        total.TotalCost = new(big.Int).Add(total.TotalCost, estimate.TotalCost)
+        total.MaxFeePerGas = estimate.MaxFeePerGas
+        total.MaxPriorityFeePerGas = estimate.MaxPriorityFeePerGas
+    }
+    return total, nil
+
+}
+
+
+// Estimate the gas cost of withdrawing a node deposit from a single minipool
+func EstimateWithdrawGas(p *services.Provider, minipoolAddress *common.Address) (*eth.GasEstimate, error) {
+
+    txor, err := p.AM.GetNodeAccountTransactor()
+    if err != nil {
+        return nil, fmt.Errorf("error creating transactor for minipool %s: %w", minipoolAddress.Hex(), err)
+    }
+    return eth.EstimateContractTransactionGas(p.Client, txor, p.NodeContractAddress, p.CM.Abis["rocketNodeContract"], "withdrawMinipoolDeposit", minipoolAddress)
+
+}
+
+
+// Withdraw node deposits from a set of minipools, shared by the interactive CLI and the api command tree
+func WithdrawBatch(p *services.Provider, minipoolAddresses []*common.Address, gasOverrides *GasOverrides) []*WithdrawResult {
+
+    results := make([]*WithdrawResult, len(minipoolAddresses))
+    for mi, minipoolAddress := range minipoolAddresses {
+        results[mi] = Withdraw(p, minipoolAddress, gasOverrides)
+    }
+    return results
+
+}
+
+
+// Withdraw a node deposit from a single minipool
+func Withdraw(p *services.Provider, minipoolAddress *common.Address, gasOverrides *GasOverrides) *WithdrawResult {
+
+    result := &WithdrawResult{Address: *minipoolAddress}
+
+    // Create transactor
+    txor, err := p.AM.GetNodeAccountTransactor()
+    if err != nil {
+        result.Error = fmt.Sprintf("error creating transactor for minipool %s: %s", minipoolAddress.Hex(), err.Error())
+        return result
+    }
+    if gasOverrides != nil {
+        if gasOverrides.MaxFeePerGas != nil { txor.GasFeeCap = gasOverrides.MaxFeePerGas }
+        if gasOverrides.MaxPriorityFeePerGas != nil { txor.GasTipCap = gasOverrides.MaxPriorityFeePerGas }
+    }
+
+    // Send withdrawal transaction
+    txReceipt, err := eth.ExecuteContractTransaction(p.Client, txor, p.NodeContractAddress, p.CM.Abis["rocketNodeContract"], "withdrawMinipoolDeposit", minipoolAddress)
+    if err != nil {
+        result.Error = fmt.Sprintf("error withdrawing deposit from minipool %s: %s", minipoolAddress.Hex(), err.Error())
+        return result
+    }
+    result.TxHash = txReceipt.TxHash
+
+    // Get withdrawal event
+    nodeWithdrawalEvents, err := eth.GetTransactionEvents(p.Client, txReceipt, minipoolAddress, p.CM.Abis["rocketMinipoolDelegateNode"], "NodeWithdrawal", NodeWithdrawalEvent{})
+    if err != nil {
+        result.Error = fmt.Sprintf("error retrieving node deposit withdrawal event for minipool %s: %s", minipoolAddress.Hex(), err.Error())
+        return result
+    }
+    if len(nodeWithdrawalEvents) == 0 {
+        result.Error = fmt.Sprintf("could not retrieve node deposit withdrawal event for minipool %s", minipoolAddress.Hex())
+        return result
+    }
+
+    nodeWithdrawalEvent := (nodeWithdrawalEvents[0]).(*NodeWithdrawalEvent)
+    result.EtherAmount = nodeWithdrawalEvent.EtherAmount
+    result.RethAmount = nodeWithdrawalEvent.RethAmount
+    result.RplAmount = nodeWithdrawalEvent.RplAmount
+
+    // Record the withdrawal in the persistent event store, so `minipool history` shows live
+    // withdrawals alongside ones reconciled from the chain
+    recordWithdrawalEvent(p, result)
+
+    return result
+
+}
@@ -0,0 +1,74 @@
+package eth
+
+import (
+    "context"
+    "fmt"
+    "math/big"
+
+    ethereum "github.com/ethereum/go-ethereum"
+    "github.com/ethereum/go-ethereum/accounts/abi"
+    "github.com/ethereum/go-ethereum/accounts/abi/bind"
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/ethereum/go-ethereum/ethclient"
+)
+
+
+// Gas cost estimate for a prospective contract transaction
+type GasEstimate struct {
+    GasLimit             uint64
+    MaxFeePerGas         *big.Int
+    MaxPriorityFeePerGas *big.Int
+    TotalCost            *big.Int // GasLimit * MaxFeePerGas
+}
+
+
+// Parse a gwei-denominated string (e.g. a CLI flag value) into wei
+func GweiToWei(gwei string) (*big.Int, error) {
+    value, ok := new(big.Float).SetString(gwei)
+    if !ok {
+        return nil, fmt.Errorf("'%s' is not a valid gwei amount", gwei)
+    }
+    wei, _ := new(big.Float).Mul(value, big.NewFloat(1e9)).Int(nil)
+    return wei, nil
+}
+
+
+// Estimate the gas required to execute a contract transaction, along with a suggested EIP-1559
+// max fee (twice the current base fee plus the suggested priority tip), without submitting it
+func EstimateContractTransactionGas(client *ethclient.Client, txor *bind.TransactOpts, toAddress common.Address, contractAbi abi.ABI, method string, params ...interface{}) (*GasEstimate, error) {
+
+    input, err := contractAbi.Pack(method, params...)
+    if err != nil {
+        return nil, fmt.Errorf("error encoding input data for %s: %w", method, err)
+    }
+
+    gasLimit, err := client.EstimateGas(context.Background(), ethereum.CallMsg{
+        From: txor.From,
+        To:   &toAddress,
+        Data: input,
+    })
+    if err != nil {
+        return nil, fmt.Errorf("error estimating gas for %s: %w", method, err)
+    }
+
+    header, err := client.HeaderByNumber(context.Background(), nil)
+    if err != nil {
+        return nil, fmt.Errorf("error getting latest block header: %w", err)
+    }
+    priorityFee, err := client.SuggestGasTipCap(context.Background())
+    if err != nil {
+        return nil, fmt.Errorf("error suggesting gas priority fee: %w", err)
+    }
+
+    // Max fee = 2x current base fee (to ride out a couple of blocks of base fee increase) + priority tip
+    maxFee := new(big.Int).Add(new(big.Int).Mul(header.BaseFee, big.NewInt(2)), priorityFee)
+    totalCost := new(big.Int).Mul(new(big.Int).SetUint64(gasLimit), maxFee)
+
+    return &GasEstimate{
+        GasLimit:             gasLimit,
+        MaxFeePerGas:         maxFee,
+        MaxPriorityFeePerGas: priorityFee,
+        TotalCost:            totalCost,
+    }, nil
+
+}
@@ -0,0 +1,101 @@
+package minipool
+
+import (
+    "io/ioutil"
+    "testing"
+
+    "github.com/rocket-pool/smartnode/shared/utils/eth"
+
+    test "github.com/rocket-pool/smartnode/tests/utils"
+    testapp "github.com/rocket-pool/smartnode/tests/utils/app"
+)
+
+
+// Test minipool bond reduction commands
+func TestMinipoolBondReduction(t *testing.T) {
+
+    // Create test app
+    app := testapp.NewApp()
+
+    // Create temporary input files
+    initInput, err := test.NewInputFile("foobarbaz" + "\n")
+    if err != nil { t.Fatal(err) }
+    initInput.Close()
+    registerInput, err := test.NewInputFile(
+        "Australia/Brisbane" + "\n" +
+        "YES" + "\n")
+    if err != nil { t.Fatal(err) }
+    registerInput.Close()
+    beginReduceInput, err := test.NewInputFile("1" + "\n" + "8" + "\n")
+    if err != nil { t.Fatal(err) }
+    beginReduceInput.Close()
+    cancelReduceInput, err := test.NewInputFile("1" + "\n")
+    if err != nil { t.Fatal(err) }
+    cancelReduceInput.Close()
+    reduceInput, err := test.NewInputFile("1" + "\n" + "8" + "\n")
+    if err != nil { t.Fatal(err) }
+    reduceInput.Close()
+
+    // Create temporary output file
+    output, err := ioutil.TempFile("", "")
+    if err != nil { t.Fatal(err) }
+    output.Close()
+
+    // Create temporary data path
+    dataPath, err := ioutil.TempDir("", "")
+    if err != nil { t.Fatal(err) }
+
+    // Get app args & options
+    beginReduceArgs := testapp.GetAppArgs(dataPath, beginReduceInput.Name(), output.Name())
+    cancelReduceArgs := testapp.GetAppArgs(dataPath, cancelReduceInput.Name(), output.Name())
+    reduceArgs := testapp.GetAppArgs(dataPath, reduceInput.Name(), output.Name())
+    initArgs := testapp.GetAppArgs(dataPath, initInput.Name(), "")
+    registerArgs := testapp.GetAppArgs(dataPath, registerInput.Name(), "")
+    appOptions := testapp.GetAppOptions(dataPath)
+
+    // Attempt to reduce bond on an uninitialised node's minipools
+    if err := app.Run(append(beginReduceArgs, "minipool", "begin-reduce-bond")); err == nil { t.Error("Should return error for uninitialised node") }
+
+    // Initialise node
+    if err := app.Run(append(initArgs, "node", "init")); err != nil { t.Fatal(err) }
+
+    // Seed node account & register node
+    if err := testapp.AppSeedNodeAccount(appOptions, eth.EthToWei(5), nil); err != nil { t.Fatal(err) }
+    if err := app.Run(append(registerArgs, "node", "register")); err != nil { t.Fatal(err) }
+
+    // Create minipools
+    minipoolAddresses, err := testapp.AppCreateNodeMinipools(appOptions, "3m", 1)
+    if err != nil { t.Fatal(err) }
+    _ = minipoolAddresses
+
+    // Attempt to finalize a bond reduction before one has begun (no minipool should be eligible)
+    if err := app.Run(append(reduceArgs, "minipool", "reduce-bond")); err == nil { t.Error("Should return error when no bond reduction is pending") }
+
+    // Begin a bond reduction on the minipool
+    if err := app.Run(append(beginReduceArgs, "minipool", "begin-reduce-bond")); err != nil { t.Error(err) }
+
+    // Check output
+    if messages, err := testapp.CheckOutput(output.Name(), []string{}, map[int][]string{
+        0: []string{"(?i)^Bond reduction to 8.00 ETH for minipool .* has begun.*$", "Begin bond reduction message incorrect"},
+    }); err != nil {
+        t.Fatal(err)
+    } else {
+        for _, msg := range messages { t.Error(msg) }
+    }
+
+    // Vote to cancel the pending bond reduction
+    if err := app.Run(append(cancelReduceArgs, "minipool", "cancel-reduce-bond")); err != nil { t.Error(err) }
+
+    // Check output
+    if messages, err := testapp.CheckOutput(output.Name(), []string{}, map[int][]string{
+        0: []string{"(?i)^Voted to cancel the bond reduction for minipool .*$", "Cancel bond reduction message incorrect"},
+    }); err != nil {
+        t.Fatal(err)
+    } else {
+        for _, msg := range messages { t.Error(msg) }
+    }
+
+    // Attempt to finalize a bond reduction after it has been cancelled (no minipool should be eligible)
+    if err := app.Run(append(reduceArgs, "minipool", "reduce-bond")); err == nil { t.Error("Should return error when no bond reduction is pending") }
+
+}
@@ -75,5 +75,34 @@ func TestMinipoolWithdraw(t *testing.T) {
         for _, msg := range messages { t.Error(msg) }
     }
 
+    // --output json without --yes should fail fast instead of blocking on a confirmation prompt
+    if err := app.Run(append(withdrawArgs, "minipool", "withdraw", "--output", "json")); err == nil {
+        t.Error("Should return error for --output json without --yes")
+    }
+
+    // --all --yes --output json with no withdrawable minipools
+    if err := app.Run(append(withdrawArgs, "minipool", "withdraw", "--all", "--yes", "--output", "json")); err != nil { t.Error(err) }
+
+    // Check output
+    if messages, err := testapp.CheckOutput(output.Name(), []string{
+        `"message":"No minipools are currently available for withdrawal"`,
+    }, map[int][]string{}); err != nil {
+        t.Fatal(err)
+    } else {
+        for _, msg := range messages { t.Error(msg) }
+    }
+
+    // --minipool with no withdrawable minipools
+    if err := app.Run(append(withdrawArgs, "minipool", "withdraw", "--minipool", minipoolAddresses[0].Hex(), "--yes")); err != nil { t.Error(err) }
+
+    // Check output
+    if messages, err := testapp.CheckOutput(output.Name(), []string{}, map[int][]string{
+        0: []string{"(?i)^No minipools are currently available for withdrawal$", "No withdrawable minipools message incorrect"},
+    }); err != nil {
+        t.Fatal(err)
+    } else {
+        for _, msg := range messages { t.Error(msg) }
+    }
+
 }
 
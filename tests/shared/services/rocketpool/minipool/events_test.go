@@ -0,0 +1,82 @@
+package minipool
+
+import (
+    "io/ioutil"
+    "math/big"
+    "os"
+    "testing"
+
+    "github.com/ethereum/go-ethereum/common"
+
+    "github.com/rocket-pool/smartnode/shared/services/rocketpool/minipool"
+)
+
+
+// Test that the persistent minipool event store dedupes and survives a reopen
+func TestEventStorePersistence(t *testing.T) {
+
+    dataPath, err := ioutil.TempDir("", "")
+    if err != nil { t.Fatal(err) }
+    defer os.RemoveAll(dataPath)
+
+    store, err := minipool.OpenEventStore(dataPath)
+    if err != nil { t.Fatal(err) }
+    if len(store.Events) != 0 { t.Error("New event store should start with no events") }
+
+    event := &minipool.Event{
+        Type:            "NodeWithdrawal",
+        MinipoolAddress: common.HexToAddress("0x1111111111111111111111111111111111111111"),
+        BlockNumber:     100,
+        TxHash:          common.HexToHash("0xaaaa"),
+        EtherAmount:     big.NewInt(1000),
+    }
+
+    // Append should persist the event
+    if err := store.Append(event); err != nil { t.Fatal(err) }
+    if len(store.Events) != 1 { t.Error("Expected 1 event after append") }
+
+    // Appending an equivalent event (same type, minipool and tx) should be a no-op
+    duplicate := &minipool.Event{
+        Type:            "NodeWithdrawal",
+        MinipoolAddress: event.MinipoolAddress,
+        BlockNumber:     event.BlockNumber,
+        TxHash:          event.TxHash,
+        EtherAmount:     big.NewInt(9999), // different amount, still considered a duplicate
+    }
+    if err := store.Append(duplicate); err != nil { t.Fatal(err) }
+    if len(store.Events) != 1 { t.Error("Duplicate event should not have been appended") }
+
+    // Reopening the store from disk should reload the persisted event
+    reopened, err := minipool.OpenEventStore(dataPath)
+    if err != nil { t.Fatal(err) }
+    if len(reopened.Events) != 1 { t.Fatal("Expected 1 event after reopening store") }
+    if reopened.Events[0].TxHash != event.TxHash { t.Error("Reopened event store has incorrect event data") }
+
+}
+
+
+// Test the human-readable formatting of persisted events
+func TestEventString(t *testing.T) {
+
+    withdrawal := &minipool.Event{
+        Type:            "NodeWithdrawal",
+        MinipoolAddress: common.HexToAddress("0x1111111111111111111111111111111111111111"),
+        BlockNumber:     42,
+        TxHash:          common.HexToHash("0xbbbb"),
+        EtherAmount:     big.NewInt(0),
+        RethAmount:      big.NewInt(0),
+        RplAmount:       big.NewInt(0),
+    }
+    if withdrawal.String() == "" { t.Error("NodeWithdrawal event should format to a non-empty string") }
+
+    status := minipool.STAKING
+    statusUpdated := &minipool.Event{
+        Type:            "StatusUpdated",
+        MinipoolAddress: common.HexToAddress("0x2222222222222222222222222222222222222222"),
+        BlockNumber:     43,
+        TxHash:          common.HexToHash("0xcccc"),
+        Status:          &status,
+    }
+    if statusUpdated.String() == "" { t.Error("StatusUpdated event should format to a non-empty string") }
+
+}